@@ -319,6 +319,115 @@ func (a *ContainerAdjustment) SetLinuxSysctl(key, value string) {
 	a.Linux.Sysctl[key] = value
 }
 
+// IsAdditiveAdjustment checks if an adjustment is purely additive for the
+// given container, IOW if it only adds annotations, environment variables,
+// mounts, hooks, rlimits, devices, namespaces, or sysctls without removing
+// or overriding any existing ones. Adding an item identical to an existing
+// one is not considered an override. Likewise, setting arguments identical
+// to the container's current ones is a no-op, while setting any other
+// arguments is not additive. Adjusting resources, the cgroups path, the OOM
+// score, the I/O priority, or the seccomp policy is never considered
+// additive, as these replace the container's settings.
+func IsAdditiveAdjustment(container *Container, adjust *ContainerAdjustment) bool {
+	if adjust == nil {
+		return true
+	}
+
+	for key, value := range adjust.Annotations {
+		if _, marked := IsMarkedForRemoval(key); marked {
+			return false
+		}
+		if old, ok := container.GetAnnotations()[key]; ok && old != value {
+			return false
+		}
+	}
+
+	if len(adjust.Env) > 0 {
+		env := map[string]string{}
+		for _, e := range FromOCIEnv(container.GetEnv()) {
+			env[e.Key] = e.Value
+		}
+		for _, e := range adjust.Env {
+			key, marked := e.IsMarkedForRemoval()
+			if marked {
+				return false
+			}
+			if old, ok := env[key]; ok && old != e.Value {
+				return false
+			}
+		}
+	}
+
+	for _, m := range adjust.Mounts {
+		key, marked := m.IsMarkedForRemoval()
+		if marked {
+			return false
+		}
+		for _, old := range container.GetMounts() {
+			if old.Destination == key && !old.Cmp(m) {
+				return false
+			}
+		}
+	}
+
+	if len(adjust.Args) > 0 && !slices.Equal(adjust.Args, container.GetArgs()) {
+		return false
+	}
+
+	for _, l := range adjust.Rlimits {
+		for _, old := range container.GetRlimits() {
+			if old.Type == l.Type && (old.Hard != l.Hard || old.Soft != l.Soft) {
+				return false
+			}
+		}
+	}
+
+	linux := adjust.GetLinux()
+	if linux == nil {
+		return true
+	}
+
+	if linux.Resources.SizeVT() != 0 || linux.CgroupsPath != "" || linux.OomScoreAdj != nil ||
+		linux.IoPriority != nil || linux.SeccompPolicy != nil {
+		return false
+	}
+
+	for _, d := range linux.Devices {
+		key, marked := d.IsMarkedForRemoval()
+		if marked {
+			return false
+		}
+		for _, old := range container.GetLinux().GetDevices() {
+			if old.Path == key && (old.Type != d.Type || old.Major != d.Major || old.Minor != d.Minor) {
+				return false
+			}
+		}
+	}
+
+	for _, n := range linux.Namespaces {
+		key, marked := n.IsMarkedForRemoval()
+		if marked {
+			return false
+		}
+		for _, old := range container.GetLinux().GetNamespaces() {
+			if old.Type == key && old.Path != n.Path {
+				return false
+			}
+		}
+	}
+
+	for key, value := range linux.Sysctl {
+		if _, marked := IsMarkedForRemoval(key); marked {
+			return false
+		}
+		if old, ok := container.GetLinux().GetSysctl()[key]; ok && old != value {
+			return false
+		}
+	}
+
+	return true
+}
+
 //
 // Initializing a container adjustment and container update.
 //
@@ -0,0 +1,185 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+func TestIsAdditiveAdjustment(t *testing.T) {
+	container := &api.Container{
+		Id:   "ctr0",
+		Name: "ctr0",
+		Annotations: map[string]string{
+			"owner": "team-a",
+		},
+		Args: []string{"/bin/sh", "-c", "sleep inf"},
+		Env:  []string{"PATH=/usr/bin:/bin", "HOME=/root"},
+		Mounts: []*api.Mount{
+			{
+				Destination: "/data",
+				Source:      "/srv/data",
+				Type:        "bind",
+				Options:     []string{"bind", "ro"},
+			},
+		},
+		Linux: &api.LinuxContainer{
+			Sysctl: map[string]string{
+				"net.ipv4.ip_forward": "0",
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		adjust   func(*api.ContainerAdjustment)
+		additive bool
+	}{
+		{
+			name:     "no adjustment",
+			adjust:   func(*api.ContainerAdjustment) {},
+			additive: true,
+		},
+		{
+			name: "additions",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.AddAnnotation("team", "a")
+				a.AddEnv("LOG_LEVEL", "debug")
+				a.AddMount(&api.Mount{
+					Destination: "/cache",
+					Source:      "/srv/cache",
+					Type:        "bind",
+					Options:     []string{"bind"},
+				})
+				a.AddHooks(&api.Hooks{
+					Prestart: []*api.Hook{{Path: "/bin/true"}},
+				})
+				a.AddDevice(&api.LinuxDevice{Path: "/dev/null", Type: "c", Major: 1, Minor: 3})
+				a.SetLinuxSysctl("net.core.somaxconn", "1024")
+			},
+			additive: true,
+		},
+		{
+			name: "re-adding existing values",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.AddAnnotation("owner", "team-a")
+				a.AddEnv("HOME", "/root")
+				a.SetLinuxSysctl("net.ipv4.ip_forward", "0")
+			},
+			additive: true,
+		},
+		{
+			name: "overriding an annotation",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.AddAnnotation("owner", "team-b")
+			},
+		},
+		{
+			name: "overriding an environment variable",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.AddEnv("PATH", "/opt/bin")
+			},
+		},
+		{
+			name: "overriding a mount",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.AddMount(&api.Mount{
+					Destination: "/data",
+					Source:      "/srv/other",
+					Type:        "bind",
+					Options:     []string{"bind", "ro"},
+				})
+			},
+		},
+		{
+			name: "overriding mount options",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.AddMount(&api.Mount{
+					Destination: "/data",
+					Source:      "/srv/data",
+					Type:        "bind",
+					Options:     []string{"bind", "rw"},
+				})
+			},
+		},
+		{
+			name: "re-adding a mount with reordered options",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.AddMount(&api.Mount{
+					Destination: "/data",
+					Source:      "/srv/data",
+					Type:        "bind",
+					Options:     []string{"ro", "bind"},
+				})
+			},
+			additive: true,
+		},
+		{
+			name: "overriding a sysctl",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.SetLinuxSysctl("net.ipv4.ip_forward", "1")
+			},
+		},
+		{
+			name: "overriding arguments",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.SetArgs([]string{"/bin/true"})
+			},
+		},
+		{
+			name: "re-setting identical args",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.SetArgs([]string{"/bin/sh", "-c", "sleep inf"})
+			},
+			additive: true,
+		},
+		{
+			name: "setting resources",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.SetLinuxMemoryLimit(1 << 30)
+			},
+		},
+		{
+			name: "removing an annotation",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.RemoveAnnotation("owner")
+			},
+		},
+		{
+			name: "removing an environment variable",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.RemoveEnv("HOME")
+			},
+		},
+		{
+			name: "removing a mount",
+			adjust: func(a *api.ContainerAdjustment) {
+				a.RemoveMount("/data")
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			adjust := &api.ContainerAdjustment{}
+			tc.adjust(adjust)
+			require.Equal(t, tc.additive, api.IsAdditiveAdjustment(container, adjust))
+		})
+	}
+}
@@ -68,7 +68,9 @@ func (m *Mount) Cmp(v *Mount) bool {
 	}
 
 	mOpts := make([]string, len(m.Options))
-	vOpts := make([]string, len(m.Options))
+	vOpts := make([]string, len(v.Options))
+	copy(mOpts, m.Options)
+	copy(vOpts, v.Options)
 	sort.Strings(mOpts)
 	sort.Strings(vOpts)
 
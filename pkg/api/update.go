@@ -153,6 +153,149 @@ func (u *ContainerUpdate) SetIgnoreFailure() {
 	u.IgnoreFailure = true
 }
 
+// MergeContainerUpdates merges the given container updates into a single
+// update per container. Updates are processed in order, with fields set in
+// later updates overriding the same fields set in earlier ones. Device cgroup
+// rules are the exception: since their order matters, they accumulate in the
+// order they appear in the updates, duplicates included. Failures of
+// a merged update are ignored only if they were ignored by all the updates
+// merged into it. The resulting updates are in the order in which their
+// containers first appear. The original updates are left intact.
+func MergeContainerUpdates(updates ...[]*ContainerUpdate) []*ContainerUpdate {
+	var (
+		merged []*ContainerUpdate
+		byID   = map[string]*ContainerUpdate{}
+	)
+
+	for _, slice := range updates {
+		for _, u := range slice {
+			if u == nil {
+				continue
+			}
+			m, ok := byID[u.ContainerId]
+			if !ok {
+				m = &ContainerUpdate{
+					ContainerId:   u.ContainerId,
+					IgnoreFailure: u.IgnoreFailure,
+				}
+				byID[u.ContainerId] = m
+				merged = append(merged, m)
+			}
+			m.merge(u)
+		}
+	}
+
+	return merged
+}
+
+// merge merges the given update into this one.
+func (u *ContainerUpdate) merge(o *ContainerUpdate) {
+	u.IgnoreFailure = u.IgnoreFailure && o.IgnoreFailure
+
+	r := o.GetLinux().GetResources()
+	if r == nil {
+		return
+	}
+
+	if m := r.Memory; m != nil {
+		if m.Limit != nil {
+			u.SetLinuxMemoryLimit(m.Limit.Value)
+		}
+		if m.Reservation != nil {
+			u.SetLinuxMemoryReservation(m.Reservation.Value)
+		}
+		if m.Swap != nil {
+			u.SetLinuxMemorySwap(m.Swap.Value)
+		}
+		if m.Kernel != nil {
+			u.SetLinuxMemoryKernel(m.Kernel.Value)
+		}
+		if m.KernelTcp != nil {
+			u.SetLinuxMemoryKernelTCP(m.KernelTcp.Value)
+		}
+		if m.Swappiness != nil {
+			u.SetLinuxMemorySwappiness(m.Swappiness.Value)
+		}
+		if m.DisableOomKiller != nil {
+			u.initLinuxResourcesMemory()
+			u.Linux.Resources.Memory.DisableOomKiller = Bool(m.DisableOomKiller.Value)
+		}
+		if m.UseHierarchy != nil {
+			u.initLinuxResourcesMemory()
+			u.Linux.Resources.Memory.UseHierarchy = Bool(m.UseHierarchy.Value)
+		}
+	}
+
+	if c := r.Cpu; c != nil {
+		if c.Shares != nil {
+			u.SetLinuxCPUShares(c.Shares.Value)
+		}
+		if c.Quota != nil {
+			u.SetLinuxCPUQuota(c.Quota.Value)
+		}
+		if c.Period != nil {
+			u.initLinuxResourcesCPU()
+			u.Linux.Resources.Cpu.Period = UInt64(c.Period.Value)
+		}
+		if c.RealtimeRuntime != nil {
+			u.SetLinuxCPURealtimeRuntime(c.RealtimeRuntime.Value)
+		}
+		if c.RealtimePeriod != nil {
+			u.SetLinuxCPURealtimePeriod(c.RealtimePeriod.Value)
+		}
+		if c.Cpus != "" {
+			u.SetLinuxCPUSetCPUs(c.Cpus)
+		}
+		if c.Mems != "" {
+			u.SetLinuxCPUSetMems(c.Mems)
+		}
+	}
+
+	for _, l := range r.HugepageLimits {
+		u.setLinuxHugepageLimit(l.PageSize, l.Limit)
+	}
+
+	for k, v := range r.Unified {
+		u.AddLinuxUnified(k, v)
+	}
+
+	if r.BlockioClass != nil {
+		u.SetLinuxBlockIOClass(r.BlockioClass.Value)
+	}
+	if r.RdtClass != nil {
+		u.SetLinuxRDTClass(r.RdtClass.Value)
+	}
+
+	if r.Pids != nil {
+		u.SetLinuxPidLimits(r.Pids.Limit)
+	}
+
+	for _, d := range r.Devices {
+		u.initLinuxResources()
+		u.Linux.Resources.Devices = append(u.Linux.Resources.Devices,
+			&LinuxDeviceCgroup{
+				Allow:  d.Allow,
+				Type:   d.Type,
+				Major:  Int64(d.Major),
+				Minor:  Int64(d.Minor),
+				Access: d.Access,
+			})
+	}
+}
+
+// setLinuxHugepageLimit sets the hugepage limit for a page size, replacing
+// any existing limit for the same page size.
+func (u *ContainerUpdate) setLinuxHugepageLimit(pageSize string, value uint64) {
+	u.initLinuxResources()
+	for _, l := range u.Linux.Resources.HugepageLimits {
+		if l.PageSize == pageSize {
+			l.Limit = value
+			return
+		}
+	}
+	u.AddLinuxHugepageLimit(pageSize, value)
+}
+
 //
 // Initializing a container update.
 //
@@ -0,0 +1,134 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+func TestMergeContainerUpdates(t *testing.T) {
+	newUpdate := func(id string, fn func(*api.ContainerUpdate)) *api.ContainerUpdate {
+		u := &api.ContainerUpdate{}
+		u.SetContainerId(id)
+		fn(u)
+		return u
+	}
+
+	t.Run("same container", func(t *testing.T) {
+		first := newUpdate("ctr0", func(u *api.ContainerUpdate) {
+			u.SetLinuxMemoryLimit(1000)
+			u.SetLinuxCPUShares(512)
+			u.AddLinuxUnified("memory.high", "800")
+			u.AddLinuxHugepageLimit("2MB", 10)
+			u.SetIgnoreFailure()
+		})
+		second := newUpdate("ctr0", func(u *api.ContainerUpdate) {
+			u.SetLinuxMemoryLimit(2000)
+			u.SetLinuxCPUSetCPUs("0-3")
+			u.AddLinuxUnified("memory.high", "1600")
+			u.AddLinuxHugepageLimit("2MB", 20)
+		})
+
+		merged := api.MergeContainerUpdates([]*api.ContainerUpdate{first}, []*api.ContainerUpdate{second})
+		require.Len(t, merged, 1)
+
+		m := merged[0]
+		require.Equal(t, "ctr0", m.ContainerId)
+		require.False(t, m.IgnoreFailure, "failure ignored only by one update")
+		require.Equal(t, int64(2000), m.Linux.Resources.Memory.Limit.Value)
+		require.Equal(t, uint64(512), m.Linux.Resources.Cpu.Shares.Value)
+		require.Equal(t, "0-3", m.Linux.Resources.Cpu.Cpus)
+		require.Equal(t, map[string]string{"memory.high": "1600"}, m.Linux.Resources.Unified)
+		require.Len(t, m.Linux.Resources.HugepageLimits, 1)
+		require.Equal(t, uint64(20), m.Linux.Resources.HugepageLimits[0].Limit)
+
+		require.Equal(t, int64(1000), first.Linux.Resources.Memory.Limit.Value, "input modified")
+	})
+
+	t.Run("device cgroup rules", func(t *testing.T) {
+		withDevices := func(rules ...*api.LinuxDeviceCgroup) func(*api.ContainerUpdate) {
+			return func(u *api.ContainerUpdate) {
+				u.Linux = &api.LinuxContainerUpdate{
+					Resources: &api.LinuxResources{
+						Devices: rules,
+					},
+				}
+			}
+		}
+		allowNull := &api.LinuxDeviceCgroup{
+			Allow:  true,
+			Type:   "c",
+			Major:  api.Int64(1),
+			Minor:  api.Int64(3),
+			Access: "rwm",
+		}
+		denyAll := &api.LinuxDeviceCgroup{
+			Type:   "a",
+			Access: "rwm",
+		}
+
+		merged := api.MergeContainerUpdates(
+			[]*api.ContainerUpdate{newUpdate("ctr0", withDevices(denyAll, allowNull))},
+			[]*api.ContainerUpdate{newUpdate("ctr0", withDevices(allowNull))},
+		)
+		require.Len(t, merged, 1)
+
+		devices := merged[0].Linux.Resources.Devices
+		require.Len(t, devices, 3)
+		require.Equal(t, "a", devices[0].Type)
+		require.False(t, devices[0].Allow)
+		require.Nil(t, devices[0].Major)
+		for _, d := range devices[1:] {
+			require.True(t, d.Allow)
+			require.Equal(t, "c", d.Type)
+			require.Equal(t, int64(1), d.Major.Value)
+			require.Equal(t, int64(3), d.Minor.Value)
+			require.Equal(t, "rwm", d.Access)
+		}
+		require.NotSame(t, allowNull, devices[1], "input rule shared")
+	})
+
+	t.Run("different containers", func(t *testing.T) {
+		merged := api.MergeContainerUpdates(
+			[]*api.ContainerUpdate{
+				newUpdate("ctr1", func(u *api.ContainerUpdate) { u.SetLinuxMemoryLimit(1000) }),
+				newUpdate("ctr0", func(u *api.ContainerUpdate) { u.SetLinuxPidLimits(100) }),
+			},
+			[]*api.ContainerUpdate{
+				newUpdate("ctr1", func(u *api.ContainerUpdate) { u.SetLinuxMemorySwappiness(0) }),
+				newUpdate("ctr2", func(u *api.ContainerUpdate) { u.SetIgnoreFailure() }),
+			},
+		)
+		require.Len(t, merged, 3)
+
+		require.Equal(t, "ctr1", merged[0].ContainerId)
+		require.Equal(t, int64(1000), merged[0].Linux.Resources.Memory.Limit.Value)
+		require.NotNil(t, merged[0].Linux.Resources.Memory.Swappiness)
+		require.Equal(t, uint64(0), merged[0].Linux.Resources.Memory.Swappiness.Value)
+
+		require.Equal(t, "ctr0", merged[1].ContainerId)
+		require.Equal(t, int64(100), merged[1].Linux.Resources.Pids.Limit)
+
+		require.Equal(t, "ctr2", merged[2].ContainerId)
+		require.True(t, merged[2].IgnoreFailure)
+		require.Nil(t, merged[2].Linux)
+	})
+}
@@ -0,0 +1,126 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/nri/pkg/api"
+)
+
+var (
+	// ErrCgroupNotDelegated is returned when trying to write to a cgroup
+	// which is not delegated to the calling process.
+	ErrCgroupNotDelegated = errors.New("cgroup not delegated")
+)
+
+// SetMemorySwapMax sets the hard swap limit (memory.swap.max) of the cgroup v2
+// directory at the given absolute path. CgroupUnlimited removes the limit.
+func SetMemorySwapMax(cgroupAbsPath string, value uint64) error {
+	return writeCgroupFile(cgroupAbsPath, "memory.swap.max", formatLimitValue(value))
+}
+
+// SetCPUMaxBurst sets the CPU bandwidth burst (cpu.max.burst), in
+// microseconds, of the cgroup v2 directory at the given absolute path. The
+// burst must not exceed the CPU bandwidth quota set in cpu.max.
+func SetCPUMaxBurst(cgroupAbsPath string, burst uint64) error {
+	quota, _, err := readCPUMax(cgroupAbsPath)
+	if err != nil {
+		return err
+	}
+	if quota != CgroupUnlimited && burst > quota {
+		return fmt.Errorf("CPU burst %d exceeds CPU quota %d of cgroup %s",
+			burst, quota, cgroupAbsPath)
+	}
+
+	return writeCgroupFile(cgroupAbsPath, "cpu.max.burst", strconv.FormatUint(burst, 10))
+}
+
+// writeCgroupFile writes the given value to an existing file of the cgroup v2
+// directory at the given absolute path, provided the cgroup is delegated to
+// the calling process.
+func writeCgroupFile(cgroupAbsPath, file, value string) error {
+	delegated, err := IsCgroupDelegated(cgroupAbsPath)
+	if err != nil {
+		return err
+	}
+	if !delegated {
+		return fmt.Errorf("failed to write %s: %w: %s", file, ErrCgroupNotDelegated, cgroupAbsPath)
+	}
+
+	name := filepath.Join(cgroupAbsPath, file)
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(value); err != nil {
+		return fmt.Errorf("failed to write %q to %s: %w", value, name, err)
+	}
+
+	return nil
+}
+
+// formatLimitValue formats a limit value for writing, with CgroupUnlimited
+// formatted as "max".
+func formatLimitValue(value uint64) string {
+	if value == CgroupUnlimited {
+		return "max"
+	}
+	return strconv.FormatUint(value, 10)
+}
+
+// QoSCgroupPath returns the cgroup path of the Kubernetes QoS class the pod
+// belongs to, derived from the cgroup parent of the pod. With the cgroupfs
+// driver this is the parent directory of the pod cgroup, for instance
+// /kubepods/burstable for /kubepods/burstable/pod<uid>. With the systemd
+// driver this is the parent slice, for instance kubepods-burstable.slice for
+// kubepods-burstable-pod<uid>.slice. Guaranteed pods have no separate QoS
+// cgroup, so for them the top level kubepods cgroup is returned.
+func QoSCgroupPath(pod *api.PodSandbox) (string, error) {
+	parent := pod.GetLinux().GetCgroupParent()
+	if parent == "" {
+		return "", fmt.Errorf("pod %s/%s has no cgroup parent",
+			pod.GetNamespace(), pod.GetName())
+	}
+
+	dir, leaf := path.Split(path.Clean(parent))
+
+	if slice, ok := strings.CutSuffix(leaf, ".slice"); ok {
+		idx := strings.LastIndex(slice, "-")
+		if idx <= 0 || !strings.HasPrefix(slice[idx+1:], "pod") {
+			return "", fmt.Errorf("invalid pod cgroup parent %q", parent)
+		}
+		if dir != "" {
+			return path.Clean(dir), nil
+		}
+		return slice[:idx] + ".slice", nil
+	}
+
+	if !strings.HasPrefix(leaf, "pod") || dir == "" || path.Clean(dir) == "/" {
+		return "", fmt.Errorf("invalid pod cgroup parent %q", parent)
+	}
+
+	return path.Clean(dir), nil
+}
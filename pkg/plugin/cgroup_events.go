@@ -0,0 +1,66 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugin
+
+// CgroupEventType is the type of an event reported for a cgroup.
+type CgroupEventType int
+
+const (
+	// CgroupPopulated is reported when a cgroup gets populated, IOW when a
+	// process appears in it or in any of its descendants.
+	CgroupPopulated CgroupEventType = iota
+	// CgroupUnpopulated is reported when the last process of a cgroup and
+	// its descendants exits.
+	CgroupUnpopulated
+	// CgroupOOM is reported when the cgroup hits its memory limit and the
+	// OOM killer is about to be invoked (oom in memory.events).
+	CgroupOOM
+	// CgroupOOMKill is reported when a process of the cgroup is killed by
+	// the OOM killer (oom_kill in memory.events).
+	CgroupOOMKill
+	// CgroupRemoved is reported when the cgroup is removed. It is the last
+	// event reported for a cgroup.
+	CgroupRemoved
+)
+
+// CgroupEvent is an event reported for a cgroup.
+type CgroupEvent struct {
+	// Type is the type of the event.
+	Type CgroupEventType
+	// Path is the absolute path of the cgroup.
+	Path string
+	// Count is the new value of the memory.events counter for CgroupOOM
+	// and CgroupOOMKill events. It is 0 for other events.
+	Count uint64
+}
+
+// String returns the event type as a string.
+func (t CgroupEventType) String() string {
+	switch t {
+	case CgroupPopulated:
+		return "populated"
+	case CgroupUnpopulated:
+		return "unpopulated"
+	case CgroupOOM:
+		return "oom"
+	case CgroupOOMKill:
+		return "oom_kill"
+	case CgroupRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
@@ -0,0 +1,233 @@
+//go:build linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// IsCgroupDelegated checks if the cgroup v2 directory at the given absolute
+// path is delegated to the calling process, IOW whether the process can
+// create child cgroups in it, move processes and threads into it, and enable
+// controllers for its children. Following the cgroup v2 delegation model,
+// this requires write access to the directory itself and to its
+// cgroup.procs, cgroup.threads, and cgroup.subtree_control files. Access is
+// checked using the effective user and group IDs of the process. A cgroup
+// which is not delegated is reported as such instead of an error, so callers
+// can tell missing delegation apart from other failures.
+func IsCgroupDelegated(cgroupPath string) (bool, error) {
+	info, err := os.Stat(cgroupPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat cgroup %q: %w", cgroupPath, err)
+	}
+	if !info.IsDir() {
+		return false, fmt.Errorf("cgroup %q is not a directory", cgroupPath)
+	}
+
+	paths := []string{
+		cgroupPath,
+		filepath.Join(cgroupPath, "cgroup.procs"),
+		filepath.Join(cgroupPath, "cgroup.threads"),
+		filepath.Join(cgroupPath, "cgroup.subtree_control"),
+	}
+
+	for _, path := range paths {
+		err := unix.Faccessat(unix.AT_FDCWD, path, unix.W_OK, unix.AT_EACCESS)
+		switch {
+		case err == nil:
+		case errors.Is(err, unix.EACCES), errors.Is(err, unix.EPERM), errors.Is(err, unix.EROFS):
+			return false, nil
+		default:
+			return false, fmt.Errorf("failed to check access to %q: %w", path, err)
+		}
+	}
+
+	return true, nil
+}
+
+// WatchCgroupEvents watches the cgroup v2 directory at the given absolute
+// path for changes in its cgroup.events and memory.events files. Events are
+// delivered on the returned channel until the context is cancelled or the
+// cgroup is removed, in which case a final CgroupRemoved event is delivered.
+// The channel is closed once watching stops. memory.events is watched only
+// if the memory controller is enabled for the cgroup. Delivery blocks until
+// the event is received, so callers must keep draining the channel or cancel
+// the context. Otherwise the watcher goroutine and its inotify instance leak.
+func WatchCgroupEvents(ctx context.Context, cgroupAbsPath string) (<-chan CgroupEvent, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inotify instance: %w", err)
+	}
+	f := os.NewFile(uintptr(fd), "inotify")
+
+	w := &cgroupWatcher{
+		path:   cgroupAbsPath,
+		file:   f,
+		events: make(chan CgroupEvent, 16),
+	}
+
+	if w.dirWd, err = unix.InotifyAddWatch(fd, cgroupAbsPath, unix.IN_DELETE_SELF); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to watch cgroup %q: %w", cgroupAbsPath, err)
+	}
+	if w.cgroupWd, err = unix.InotifyAddWatch(fd, w.cgroupEvents(), unix.IN_MODIFY); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to watch %q: %w", w.cgroupEvents(), err)
+	}
+	w.memoryWd, err = unix.InotifyAddWatch(fd, w.memoryEvents(), unix.IN_MODIFY)
+	if err != nil {
+		if !errors.Is(err, unix.ENOENT) {
+			f.Close()
+			return nil, fmt.Errorf("failed to watch %q: %w", w.memoryEvents(), err)
+		}
+		w.memoryWd = -1
+	}
+
+	w.populated, _ = w.readPopulated()
+	w.oom, w.oomKill = w.readOOMCounters()
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		f.Close()
+	}()
+	go w.run(ctx, done)
+
+	return w.events, nil
+}
+
+type cgroupWatcher struct {
+	path      string
+	file      *os.File
+	events    chan CgroupEvent
+	dirWd     int
+	cgroupWd  int
+	memoryWd  int
+	populated bool
+	oom       uint64
+	oomKill   uint64
+}
+
+func (w *cgroupWatcher) cgroupEvents() string {
+	return filepath.Join(w.path, "cgroup.events")
+}
+
+func (w *cgroupWatcher) memoryEvents() string {
+	return filepath.Join(w.path, "memory.events")
+}
+
+func (w *cgroupWatcher) run(ctx context.Context, done chan struct{}) {
+	defer close(w.events)
+	defer close(done)
+
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			return
+		}
+
+		for offs := 0; offs+unix.SizeofInotifyEvent <= n; {
+			e := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offs]))
+			offs += unix.SizeofInotifyEvent + int(e.Len)
+
+			switch {
+			case int(e.Wd) == w.dirWd && e.Mask&(unix.IN_DELETE_SELF|unix.IN_IGNORED) != 0:
+				w.send(ctx, CgroupEvent{Type: CgroupRemoved, Path: w.path})
+				return
+			case e.Mask&unix.IN_MODIFY == 0:
+				continue
+			case int(e.Wd) == w.cgroupWd:
+				w.checkPopulated(ctx)
+			case int(e.Wd) == w.memoryWd:
+				w.checkOOM(ctx)
+			}
+		}
+	}
+}
+
+func (w *cgroupWatcher) checkPopulated(ctx context.Context) {
+	populated, err := w.readPopulated()
+	if err != nil || populated == w.populated {
+		return
+	}
+
+	w.populated = populated
+	if populated {
+		w.send(ctx, CgroupEvent{Type: CgroupPopulated, Path: w.path})
+	} else {
+		w.send(ctx, CgroupEvent{Type: CgroupUnpopulated, Path: w.path})
+	}
+}
+
+func (w *cgroupWatcher) checkOOM(ctx context.Context) {
+	oom, oomKill := w.readOOMCounters()
+	if oom > w.oom {
+		w.send(ctx, CgroupEvent{Type: CgroupOOM, Path: w.path, Count: oom})
+	}
+	if oomKill > w.oomKill {
+		w.send(ctx, CgroupEvent{Type: CgroupOOMKill, Path: w.path, Count: oomKill})
+	}
+	w.oom, w.oomKill = oom, oomKill
+}
+
+func (w *cgroupWatcher) readPopulated() (bool, error) {
+	entries, err := readFlatKeyedFile(w.cgroupEvents())
+	if err != nil {
+		return false, err
+	}
+	populated, ok := entries["populated"]
+	if !ok {
+		return false, fmt.Errorf("%s: missing populated entry", w.cgroupEvents())
+	}
+	return populated != 0, nil
+}
+
+func (w *cgroupWatcher) readOOMCounters() (uint64, uint64) {
+	if w.memoryWd < 0 {
+		return 0, 0
+	}
+	entries, err := readFlatKeyedFile(w.memoryEvents())
+	if err != nil {
+		return w.oom, w.oomKill
+	}
+	oom, ok1 := entries["oom"]
+	oomKill, ok2 := entries["oom_kill"]
+	if !ok1 || !ok2 {
+		return w.oom, w.oomKill
+	}
+	return oom, oomKill
+}
+
+func (w *cgroupWatcher) send(ctx context.Context, e CgroupEvent) {
+	select {
+	case w.events <- e:
+	case <-ctx.Done():
+	}
+}
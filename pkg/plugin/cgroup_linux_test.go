@@ -0,0 +1,262 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugin_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/nri/pkg/plugin"
+)
+
+func TestIsCgroupDelegated(t *testing.T) {
+	// Notes:
+	//   Access checks always succeed for root, so the read-only cases
+	//   below are skipped when running as root. The missing file case
+	//   is the negative check which runs for root as well.
+
+	t.Run("writable cgroup", func(t *testing.T) {
+		dir := newFakeCgroup(t, 0o755, 0o644)
+		delegated, err := plugin.IsCgroupDelegated(dir)
+		require.NoError(t, err)
+		require.True(t, delegated)
+	})
+
+	t.Run("read-only cgroup files", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("access checks always succeed for root")
+		}
+		dir := newFakeCgroup(t, 0o755, 0o444)
+		delegated, err := plugin.IsCgroupDelegated(dir)
+		require.NoError(t, err)
+		require.False(t, delegated)
+	})
+
+	t.Run("read-only cgroup directory", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("access checks always succeed for root")
+		}
+		dir := newFakeCgroup(t, 0o555, 0o644)
+		delegated, err := plugin.IsCgroupDelegated(dir)
+		require.NoError(t, err)
+		require.False(t, delegated)
+	})
+
+	t.Run("missing cgroup.subtree_control", func(t *testing.T) {
+		dir := newFakeCgroup(t, 0o755, 0o644)
+		require.NoError(t, os.Remove(filepath.Join(dir, "cgroup.subtree_control")))
+		delegated, err := plugin.IsCgroupDelegated(dir)
+		require.ErrorIs(t, err, os.ErrNotExist)
+		require.False(t, delegated)
+	})
+
+	t.Run("missing cgroup", func(t *testing.T) {
+		_, err := plugin.IsCgroupDelegated(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		dir := newFakeCgroup(t, 0o755, 0o644)
+		_, err := plugin.IsCgroupDelegated(filepath.Join(dir, "cgroup.procs"))
+		require.Error(t, err)
+	})
+}
+
+func TestSetMemorySwapMax(t *testing.T) {
+	newSwapCgroup := func(t *testing.T, dirMode os.FileMode) string {
+		dir := newFakeCgroup(t, 0o755, 0o644)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "memory.swap.max"), []byte("max\n"), 0o644))
+		require.NoError(t, os.Chmod(dir, dirMode))
+		return dir
+	}
+
+	readSwapMax := func(t *testing.T, dir string) string {
+		data, err := os.ReadFile(filepath.Join(dir, "memory.swap.max"))
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("numeric limit", func(t *testing.T) {
+		dir := newSwapCgroup(t, 0o755)
+		require.NoError(t, plugin.SetMemorySwapMax(dir, 1<<30))
+		require.Equal(t, "1073741824", readSwapMax(t, dir))
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		dir := newSwapCgroup(t, 0o755)
+		require.NoError(t, plugin.SetMemorySwapMax(dir, 1<<30))
+		require.NoError(t, plugin.SetMemorySwapMax(dir, plugin.CgroupUnlimited))
+		require.Equal(t, "max", readSwapMax(t, dir))
+	})
+
+	t.Run("no swap accounting", func(t *testing.T) {
+		dir := newFakeCgroup(t, 0o755, 0o644)
+		err := plugin.SetMemorySwapMax(dir, 1<<30)
+		require.ErrorIs(t, err, os.ErrNotExist)
+		_, err = os.Stat(filepath.Join(dir, "memory.swap.max"))
+		require.ErrorIs(t, err, os.ErrNotExist, "swap limit file should not be created")
+	})
+
+	t.Run("not delegated", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("access checks always succeed for root")
+		}
+		dir := newSwapCgroup(t, 0o555)
+		err := plugin.SetMemorySwapMax(dir, 1<<30)
+		require.ErrorIs(t, err, plugin.ErrCgroupNotDelegated)
+		require.Equal(t, "max\n", readSwapMax(t, dir))
+	})
+}
+
+func TestSetCPUMaxBurst(t *testing.T) {
+	newCPUCgroup := func(t *testing.T, cpuMax string) string {
+		dir := newFakeCgroup(t, 0o755, 0o644)
+		for file, content := range map[string]string{
+			"cpu.max":       cpuMax,
+			"cpu.max.burst": "0\n",
+		} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644))
+		}
+		return dir
+	}
+
+	t.Run("burst within quota", func(t *testing.T) {
+		dir := newCPUCgroup(t, "50000 100000\n")
+		require.NoError(t, plugin.SetCPUMaxBurst(dir, 50000))
+		burst, supported, err := plugin.ReadCPUMaxBurst(dir)
+		require.NoError(t, err)
+		require.True(t, supported)
+		require.Equal(t, uint64(50000), burst)
+	})
+
+	t.Run("burst exceeding quota", func(t *testing.T) {
+		dir := newCPUCgroup(t, "50000 100000\n")
+		require.ErrorContains(t, plugin.SetCPUMaxBurst(dir, 50001), "exceeds CPU quota")
+		burst, _, err := plugin.ReadCPUMaxBurst(dir)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), burst)
+	})
+
+	t.Run("unlimited quota", func(t *testing.T) {
+		dir := newCPUCgroup(t, "max 100000\n")
+		require.NoError(t, plugin.SetCPUMaxBurst(dir, 1000000))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		dir := newCPUCgroup(t, "50000 100000\n")
+		require.NoError(t, os.Remove(filepath.Join(dir, "cpu.max.burst")))
+		require.ErrorIs(t, plugin.SetCPUMaxBurst(dir, 1000), os.ErrNotExist)
+	})
+
+	t.Run("not delegated", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("access checks always succeed for root")
+		}
+		dir := newCPUCgroup(t, "50000 100000\n")
+		require.NoError(t, os.Chmod(dir, 0o555))
+		require.ErrorIs(t, plugin.SetCPUMaxBurst(dir, 1000), plugin.ErrCgroupNotDelegated)
+	})
+}
+
+func TestWatchCgroupEvents(t *testing.T) {
+	t.Run("events until removal", func(t *testing.T) {
+		cgroup := writeCgroupFiles(t, map[string]string{
+			"cgroup.events": "populated 1\nfrozen 0\n",
+			"memory.events": "low 0\nhigh 0\nmax 0\noom 0\noom_kill 0\n",
+		})
+
+		events, err := plugin.WatchCgroupEvents(context.Background(), cgroup)
+		require.NoError(t, err)
+
+		overwriteCgroupFile(t, cgroup, "memory.events", "low 0\nhigh 0\nmax 3\noom 1\noom_kill 1\n")
+		requireCgroupEvent(t, events, plugin.CgroupOOM, 1)
+		requireCgroupEvent(t, events, plugin.CgroupOOMKill, 1)
+
+		overwriteCgroupFile(t, cgroup, "cgroup.events", "populated 0\nfrozen 0\n")
+		requireCgroupEvent(t, events, plugin.CgroupUnpopulated, 0)
+
+		overwriteCgroupFile(t, cgroup, "cgroup.events", "populated 1\nfrozen 0\n")
+		requireCgroupEvent(t, events, plugin.CgroupPopulated, 0)
+
+		require.NoError(t, os.RemoveAll(cgroup))
+		requireCgroupEvent(t, events, plugin.CgroupRemoved, 0)
+
+		_, ok := <-events
+		require.False(t, ok, "event channel should be closed")
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		cgroup := writeCgroupFiles(t, map[string]string{
+			"cgroup.events": "populated 1\nfrozen 0\n",
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := plugin.WatchCgroupEvents(ctx, cgroup)
+		require.NoError(t, err)
+
+		cancel()
+		select {
+		case _, ok := <-events:
+			require.False(t, ok, "event channel should be closed")
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timeout waiting for event channel to close")
+		}
+	})
+
+	t.Run("missing cgroup", func(t *testing.T) {
+		_, err := plugin.WatchCgroupEvents(context.Background(), filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+}
+
+// overwriteCgroupFile rewrites a file in place without truncating it first,
+// so that the watcher never sees it empty. Content must not be shorter than
+// the original.
+func overwriteCgroupFile(t *testing.T, dir, name, content string) {
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func requireCgroupEvent(t *testing.T, events <-chan plugin.CgroupEvent, typ plugin.CgroupEventType, count uint64) {
+	select {
+	case e, ok := <-events:
+		require.True(t, ok, "event channel closed, expected %s event", typ)
+		require.Equal(t, typ, e.Type, "unexpected %s event", e.Type)
+		require.Equal(t, count, e.Count)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for "+typ.String()+" event")
+	}
+}
+
+func newFakeCgroup(t *testing.T, dirMode, fileMode os.FileMode) string {
+	dir := t.TempDir()
+	for _, file := range []string{"cgroup.procs", "cgroup.threads", "cgroup.subtree_control"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, fileMode))
+	}
+	require.NoError(t, os.Chmod(dir, dirMode))
+	// restore permissions so that the temporary directory can be cleaned up
+	t.Cleanup(func() { os.Chmod(dir, 0o755) })
+	return dir
+}
@@ -0,0 +1,36 @@
+//go:build !linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// IsCgroupDelegated checks if the cgroup at the given path is delegated to
+// the calling process.
+func IsCgroupDelegated(cgroupPath string) (bool, error) {
+	return false, fmt.Errorf("IsCgroupDelegated() unimplemented on %s", runtime.GOOS)
+}
+
+// WatchCgroupEvents watches the cgroup at the given path for events.
+func WatchCgroupEvents(ctx context.Context, cgroupAbsPath string) (<-chan CgroupEvent, error) {
+	return nil, fmt.Errorf("WatchCgroupEvents() unimplemented on %s", runtime.GOOS)
+}
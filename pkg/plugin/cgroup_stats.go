@@ -0,0 +1,468 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CgroupUnlimited is the value reported for limits set to "max".
+	CgroupUnlimited = uint64(math.MaxUint64)
+)
+
+// CgroupStat contains the descendant counters of a cgroup v2 cgroup.stat file.
+type CgroupStat struct {
+	// NrDescendants is the number of visible descendant cgroups.
+	NrDescendants uint64
+	// NrDyingDescendants is the number of descendant cgroups which have been
+	// removed but are still being torn down by the kernel. A steadily growing
+	// value usually indicates a cgroup leak.
+	NrDyingDescendants uint64
+}
+
+// ReadCgroupStat reads cgroup.stat of the cgroup v2 directory at the given
+// absolute path.
+func ReadCgroupStat(cgroupAbsPath string) (*CgroupStat, error) {
+	entries, err := readFlatKeyedFile(filepath.Join(cgroupAbsPath, "cgroup.stat"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CgroupStat{
+		NrDescendants:      entries["nr_descendants"],
+		NrDyingDescendants: entries["nr_dying_descendants"],
+	}, nil
+}
+
+// MemoryStats contains memory statistics of a cgroup v2 cgroup.
+type MemoryStats struct {
+	// Current is the total memory usage of the cgroup (memory.current).
+	Current uint64
+	// Max is the hard memory limit (memory.max) or CgroupUnlimited.
+	Max uint64
+	// High is the memory throttling limit (memory.high) or CgroupUnlimited.
+	High uint64
+	// HasSwap is true if swap accounting is available for the cgroup. It
+	// is not available if the kernel is configured without it, or if it
+	// is disabled using the swapaccount=0 kernel command line option.
+	HasSwap bool
+	// SwapCurrent is the swap usage of the cgroup (memory.swap.current).
+	// It is 0 if swap accounting is not available.
+	SwapCurrent uint64
+	// SwapMax is the hard swap limit (memory.swap.max) or CgroupUnlimited.
+	// It is 0 if swap accounting is not available.
+	SwapMax uint64
+	// Anon is the amount of anonymous memory (anon in memory.stat).
+	Anon uint64
+	// File is the amount of page cache memory (file in memory.stat).
+	File uint64
+	// Kernel is the amount of kernel memory (kernel in memory.stat).
+	Kernel uint64
+}
+
+// ReadMemoryStats reads memory statistics of the cgroup v2 directory at the
+// given absolute path. Swap statistics are optional and only read if swap
+// accounting is available.
+func ReadMemoryStats(cgroupAbsPath string) (*MemoryStats, error) {
+	var (
+		stats = &MemoryStats{}
+		err   error
+	)
+
+	if stats.Current, err = readSingleValueFile(filepath.Join(cgroupAbsPath, "memory.current")); err != nil {
+		return nil, err
+	}
+	if stats.Max, err = readSingleValueFile(filepath.Join(cgroupAbsPath, "memory.max")); err != nil {
+		return nil, err
+	}
+	if stats.High, err = readSingleValueFile(filepath.Join(cgroupAbsPath, "memory.high")); err != nil {
+		return nil, err
+	}
+	stats.SwapCurrent, err = readSingleValueFile(filepath.Join(cgroupAbsPath, "memory.swap.current"))
+	switch {
+	case err == nil:
+		stats.HasSwap = true
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+	if stats.HasSwap {
+		if stats.SwapMax, err = readSingleValueFile(filepath.Join(cgroupAbsPath, "memory.swap.max")); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := readFlatKeyedFile(filepath.Join(cgroupAbsPath, "memory.stat"))
+	if err != nil {
+		return nil, err
+	}
+	stats.Anon = entries["anon"]
+	stats.File = entries["file"]
+	stats.Kernel = entries["kernel"]
+
+	return stats, nil
+}
+
+// CPUStats contains CPU statistics of a cgroup v2 cgroup.
+type CPUStats struct {
+	// UsageUsec is the total CPU time consumed, in microseconds.
+	UsageUsec uint64
+	// UserUsec is the user CPU time consumed, in microseconds.
+	UserUsec uint64
+	// SystemUsec is the system CPU time consumed, in microseconds.
+	SystemUsec uint64
+	// NrPeriods is the number of elapsed enforcement periods.
+	NrPeriods uint64
+	// NrThrottled is the number of periods the cgroup was throttled in.
+	NrThrottled uint64
+	// ThrottledUsec is the total time the cgroup was throttled, in microseconds.
+	ThrottledUsec uint64
+	// HasBandwidth is true if CPU bandwidth control (cpu.max) is available.
+	HasBandwidth bool
+	// Quota is the CPU bandwidth quota from cpu.max or CgroupUnlimited.
+	Quota uint64
+	// Period is the CPU bandwidth period from cpu.max, in microseconds, or 0
+	// if CPU bandwidth control is not available.
+	Period uint64
+}
+
+// ReadCPUStats reads CPU statistics of the cgroup v2 directory at the given
+// absolute path. cpu.max only exists if the cpu controller is enabled for the
+// cgroup, if it is missing HasBandwidth is false and Quota is CgroupUnlimited.
+func ReadCPUStats(cgroupAbsPath string) (*CPUStats, error) {
+	entries, err := readFlatKeyedFile(filepath.Join(cgroupAbsPath, "cpu.stat"))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &CPUStats{
+		UsageUsec:     entries["usage_usec"],
+		UserUsec:      entries["user_usec"],
+		SystemUsec:    entries["system_usec"],
+		NrPeriods:     entries["nr_periods"],
+		NrThrottled:   entries["nr_throttled"],
+		ThrottledUsec: entries["throttled_usec"],
+		Quota:         CgroupUnlimited,
+	}
+
+	quota, period, err := readCPUMax(cgroupAbsPath)
+	switch {
+	case err == nil:
+		stats.HasBandwidth = true
+		stats.Quota = quota
+		stats.Period = period
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// ReadCPUMaxBurst reads the CPU bandwidth burst (cpu.max.burst), in
+// microseconds, of the cgroup v2 directory at the given absolute path. The
+// returned boolean is false if the kernel does not support bandwidth burst,
+// IOW if cpu.max.burst does not exist.
+func ReadCPUMaxBurst(cgroupAbsPath string) (uint64, bool, error) {
+	burst, err := readSingleValueFile(filepath.Join(cgroupAbsPath, "cpu.max.burst"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return burst, true, nil
+}
+
+// PSIData contains one line of pressure stall information.
+type PSIData struct {
+	// Avg10 is the share of time stalled over the last 10 seconds, in percent.
+	Avg10 float64
+	// Avg60 is the share of time stalled over the last 60 seconds, in percent.
+	Avg60 float64
+	// Avg300 is the share of time stalled over the last 300 seconds, in percent.
+	Avg300 float64
+	// Total is the total time stalled, in microseconds.
+	Total uint64
+}
+
+// PSIStats contains pressure stall information for a resource of a cgroup.
+type PSIStats struct {
+	// Some is the pressure when at least some tasks were stalled.
+	Some PSIData
+	// Full is the pressure when all tasks were stalled. It is nil if the
+	// kernel does not report it, which can be the case for cpu.pressure.
+	Full *PSIData
+}
+
+// ReadPSI reads pressure stall information for the given resource of the
+// cgroup v2 directory at the given absolute path. Resource must be one of
+// "cpu", "memory", or "io".
+func ReadPSI(cgroupAbsPath, resource string) (*PSIStats, error) {
+	switch resource {
+	case "cpu", "memory", "io":
+	default:
+		return nil, fmt.Errorf("invalid PSI resource %q, expecting cpu, memory, or io", resource)
+	}
+
+	path := filepath.Join(cgroupAbsPath, resource+".pressure")
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var (
+		stats   = &PSIStats{}
+		hasSome bool
+	)
+
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		kind, rest, _ := strings.Cut(line, " ")
+		data, err := parsePSIData(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		switch kind {
+		case "some":
+			stats.Some = *data
+			hasSome = true
+		case "full":
+			stats.Full = data
+		default:
+			return nil, fmt.Errorf("%s:%d: invalid entry %q", path, lineNo, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if !hasSome {
+		return nil, fmt.Errorf("%s: missing some entry", path)
+	}
+
+	return stats, nil
+}
+
+// parsePSIData parses the avg10=X avg60=X avg300=X total=X fields of a PSI line.
+func parsePSIData(fields string) (*PSIData, error) {
+	var (
+		data = &PSIData{}
+		seen = 0
+	)
+
+	for _, field := range strings.Fields(fields) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid field %q", field)
+		}
+
+		var err error
+		switch key {
+		case "avg10":
+			data.Avg10, err = strconv.ParseFloat(value, 64)
+		case "avg60":
+			data.Avg60, err = strconv.ParseFloat(value, 64)
+		case "avg300":
+			data.Avg300, err = strconv.ParseFloat(value, 64)
+		case "total":
+			data.Total, err = strconv.ParseUint(value, 10, 64)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		seen++
+	}
+
+	if seen != 4 {
+		return nil, fmt.Errorf("incomplete entry %q", fields)
+	}
+
+	return data, nil
+}
+
+// IODeviceStats contains I/O statistics of a cgroup v2 cgroup for a device.
+type IODeviceStats struct {
+	// Rbytes is the number of bytes read.
+	Rbytes uint64
+	// Wbytes is the number of bytes written.
+	Wbytes uint64
+	// Rios is the number of read operations.
+	Rios uint64
+	// Wios is the number of write operations.
+	Wios uint64
+	// Dbytes is the number of bytes discarded.
+	Dbytes uint64
+	// Dios is the number of discard operations.
+	Dios uint64
+}
+
+// ReadIOStats reads I/O statistics of the cgroup v2 directory at the given
+// absolute path. The statistics are keyed by device number in major:minor
+// format. An empty map is returned if the cgroup has no io.stat, IOW if the
+// io controller is not enabled for it.
+func ReadIOStats(cgroupAbsPath string) (map[string]*IODeviceStats, error) {
+	path := filepath.Join(cgroupAbsPath, "io.stat")
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]*IODeviceStats{}, nil
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	stats := map[string]*IODeviceStats{}
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		dev := &IODeviceStats{}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("%s:%d: invalid field %q", path, lineNo, field)
+			}
+
+			var ptr *uint64
+			switch key {
+			case "rbytes":
+				ptr = &dev.Rbytes
+			case "wbytes":
+				ptr = &dev.Wbytes
+			case "rios":
+				ptr = &dev.Rios
+			case "wios":
+				ptr = &dev.Wios
+			case "dbytes":
+				ptr = &dev.Dbytes
+			case "dios":
+				ptr = &dev.Dios
+			default:
+				continue
+			}
+
+			if *ptr, err = strconv.ParseUint(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid value for %s: %w", path, lineNo, key, err)
+			}
+		}
+
+		stats[fields[0]] = dev
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return stats, nil
+}
+
+// readSingleValueFile reads a cgroup v2 file containing a single unsigned
+// integer value. The literal "max" is read as CgroupUnlimited.
+func readSingleValueFile(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return parseLimitValue(path, strings.TrimSpace(string(data)))
+}
+
+// parseLimitValue parses an unsigned integer value read from the given
+// file. The literal "max" is parsed as CgroupUnlimited.
+func parseLimitValue(path, value string) (uint64, error) {
+	if value == "max" {
+		return CgroupUnlimited, nil
+	}
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %q: %w", path, value, err)
+	}
+	return v, nil
+}
+
+// readCPUMax reads the CPU bandwidth quota and period from cpu.max of the
+// cgroup v2 directory at the given absolute path. An unlimited quota is
+// returned as CgroupUnlimited.
+func readCPUMax(cgroupAbsPath string) (uint64, uint64, error) {
+	path := filepath.Join(cgroupAbsPath, "cpu.max")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("%s: invalid content %q", path, strings.TrimSpace(string(data)))
+	}
+	quota, err := parseLimitValue(path, fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	period, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: invalid period %q: %w", path, fields[1], err)
+	}
+
+	return quota, period, nil
+}
+
+// readFlatKeyedFile reads a cgroup v2 flat keyed file, IOW one consisting
+// of lines with a single key and an unsigned integer value per line.
+func readFlatKeyedFile(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	entries := map[string]uint64{}
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: invalid entry %q", path, lineNo, line)
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid value for %s: %w", path, lineNo, fields[0], err)
+		}
+		entries[fields[0]] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,325 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugin_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/nri/pkg/plugin"
+)
+
+func TestReadCgroupStat(t *testing.T) {
+	t.Run("valid cgroup.stat", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"cgroup.stat": "nr_descendants 12\nnr_dying_descendants 3\n",
+		})
+		stat, err := plugin.ReadCgroupStat(dir)
+		require.NoError(t, err)
+		require.Equal(t, &plugin.CgroupStat{
+			NrDescendants:      12,
+			NrDyingDescendants: 3,
+		}, stat)
+	})
+
+	t.Run("missing cgroup.stat", func(t *testing.T) {
+		_, err := plugin.ReadCgroupStat(t.TempDir())
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("malformed cgroup.stat", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"cgroup.stat": "nr_descendants 12\nnr_dying_descendants many\n",
+		})
+		_, err := plugin.ReadCgroupStat(dir)
+		require.ErrorContains(t, err, "cgroup.stat:2")
+	})
+}
+
+func TestReadMemoryStats(t *testing.T) {
+	files := map[string]string{
+		"memory.current":      "104857600\n",
+		"memory.max":          "209715200\n",
+		"memory.high":         "max\n",
+		"memory.swap.current": "4096\n",
+		"memory.swap.max":     "max\n",
+		"memory.stat": "anon 52428800\n" +
+			"file 41943040\n" +
+			"kernel 8388608\n" +
+			"kernel_stack 131072\n" +
+			"pgfault 12345\n",
+	}
+
+	t.Run("valid memory files", func(t *testing.T) {
+		stats, err := plugin.ReadMemoryStats(writeCgroupFiles(t, files))
+		require.NoError(t, err)
+		require.Equal(t, &plugin.MemoryStats{
+			Current:     104857600,
+			Max:         209715200,
+			High:        plugin.CgroupUnlimited,
+			HasSwap:     true,
+			SwapCurrent: 4096,
+			SwapMax:     plugin.CgroupUnlimited,
+			Anon:        52428800,
+			File:        41943040,
+			Kernel:      8388608,
+		}, stats)
+	})
+
+	t.Run("limited swap", func(t *testing.T) {
+		dir := writeCgroupFiles(t, files)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "memory.swap.max"), []byte("1073741824\n"), 0o644))
+		stats, err := plugin.ReadMemoryStats(dir)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1073741824), stats.SwapMax)
+	})
+
+	t.Run("no swap accounting", func(t *testing.T) {
+		dir := writeCgroupFiles(t, files)
+		require.NoError(t, os.Remove(filepath.Join(dir, "memory.swap.current")))
+		require.NoError(t, os.Remove(filepath.Join(dir, "memory.swap.max")))
+		stats, err := plugin.ReadMemoryStats(dir)
+		require.NoError(t, err)
+		require.False(t, stats.HasSwap)
+		require.Equal(t, uint64(0), stats.SwapCurrent)
+		require.Equal(t, uint64(0), stats.SwapMax)
+		require.Equal(t, uint64(104857600), stats.Current)
+		require.Equal(t, uint64(52428800), stats.Anon)
+	})
+
+	t.Run("missing memory file", func(t *testing.T) {
+		dir := writeCgroupFiles(t, files)
+		require.NoError(t, os.Remove(filepath.Join(dir, "memory.current")))
+		_, err := plugin.ReadMemoryStats(dir)
+		require.ErrorIs(t, err, os.ErrNotExist)
+		require.ErrorContains(t, err, filepath.Join(dir, "memory.current"))
+	})
+
+	t.Run("invalid memory limit", func(t *testing.T) {
+		dir := writeCgroupFiles(t, files)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "memory.max"), []byte("unlimited\n"), 0o644))
+		_, err := plugin.ReadMemoryStats(dir)
+		require.ErrorContains(t, err, "memory.max")
+	})
+}
+
+func TestReadCPUStats(t *testing.T) {
+	cpuStat := "usage_usec 2401234\n" +
+		"user_usec 1800000\n" +
+		"system_usec 601234\n" +
+		"core_sched.force_idle_usec 0\n" +
+		"nr_periods 520\n" +
+		"nr_throttled 17\n" +
+		"throttled_usec 98765\n" +
+		"nr_bursts 0\n" +
+		"burst_usec 0\n"
+
+	t.Run("limited CPU", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"cpu.stat": cpuStat,
+			"cpu.max":  "50000 100000\n",
+		})
+		stats, err := plugin.ReadCPUStats(dir)
+		require.NoError(t, err)
+		require.Equal(t, &plugin.CPUStats{
+			UsageUsec:     2401234,
+			UserUsec:      1800000,
+			SystemUsec:    601234,
+			NrPeriods:     520,
+			NrThrottled:   17,
+			ThrottledUsec: 98765,
+			HasBandwidth:  true,
+			Quota:         50000,
+			Period:        100000,
+		}, stats)
+	})
+
+	t.Run("unlimited CPU", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"cpu.stat": cpuStat,
+			"cpu.max":  "max 100000\n",
+		})
+		stats, err := plugin.ReadCPUStats(dir)
+		require.NoError(t, err)
+		require.True(t, stats.HasBandwidth)
+		require.Equal(t, plugin.CgroupUnlimited, stats.Quota)
+		require.Equal(t, uint64(100000), stats.Period)
+	})
+
+	t.Run("missing cpu.max", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"cpu.stat": "usage_usec 2401234\n" +
+				"user_usec 1800000\n" +
+				"system_usec 601234\n",
+		})
+		stats, err := plugin.ReadCPUStats(dir)
+		require.NoError(t, err)
+		require.Equal(t, &plugin.CPUStats{
+			UsageUsec:  2401234,
+			UserUsec:   1800000,
+			SystemUsec: 601234,
+			Quota:      plugin.CgroupUnlimited,
+		}, stats)
+	})
+
+	t.Run("malformed cpu.stat", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"cpu.stat": "usage_usec 100\nuser_usec\n",
+			"cpu.max":  "max 100000\n",
+		})
+		_, err := plugin.ReadCPUStats(dir)
+		require.ErrorContains(t, err, "cpu.stat:2")
+	})
+
+	t.Run("malformed cpu.max", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"cpu.stat": cpuStat,
+			"cpu.max":  "max\n",
+		})
+		_, err := plugin.ReadCPUStats(dir)
+		require.ErrorContains(t, err, "cpu.max")
+	})
+}
+
+func TestReadCPUMaxBurst(t *testing.T) {
+	t.Run("supported", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"cpu.max.burst": "20000\n",
+		})
+		burst, supported, err := plugin.ReadCPUMaxBurst(dir)
+		require.NoError(t, err)
+		require.True(t, supported)
+		require.Equal(t, uint64(20000), burst)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		burst, supported, err := plugin.ReadCPUMaxBurst(t.TempDir())
+		require.NoError(t, err)
+		require.False(t, supported)
+		require.Equal(t, uint64(0), burst)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"cpu.max.burst": "lots\n",
+		})
+		_, _, err := plugin.ReadCPUMaxBurst(dir)
+		require.ErrorContains(t, err, "cpu.max.burst")
+	})
+}
+
+func TestReadPSI(t *testing.T) {
+	dir := writeCgroupFiles(t, map[string]string{
+		"cpu.pressure": "some avg10=1.52 avg60=0.87 avg300=0.25 total=3021994\n",
+		"memory.pressure": "some avg10=0.00 avg60=0.12 avg300=0.03 total=41233\n" +
+			"full avg10=0.00 avg60=0.05 avg300=0.01 total=20510\n",
+		"io.pressure": "some avg10=0.00 avg60=0.00 avg300=0.00 total=0\n" +
+			"full avg10=bad avg60=0.00 avg300=0.00 total=0\n",
+	})
+
+	t.Run("cpu pressure without full line", func(t *testing.T) {
+		stats, err := plugin.ReadPSI(dir, "cpu")
+		require.NoError(t, err)
+		require.Equal(t, &plugin.PSIStats{
+			Some: plugin.PSIData{
+				Avg10:  1.52,
+				Avg60:  0.87,
+				Avg300: 0.25,
+				Total:  3021994,
+			},
+		}, stats)
+	})
+
+	t.Run("memory pressure", func(t *testing.T) {
+		stats, err := plugin.ReadPSI(dir, "memory")
+		require.NoError(t, err)
+		require.Equal(t, uint64(41233), stats.Some.Total)
+		require.Equal(t, &plugin.PSIData{
+			Avg10:  0,
+			Avg60:  0.05,
+			Avg300: 0.01,
+			Total:  20510,
+		}, stats.Full)
+	})
+
+	t.Run("malformed io pressure", func(t *testing.T) {
+		_, err := plugin.ReadPSI(dir, "io")
+		require.ErrorContains(t, err, "io.pressure:2")
+	})
+
+	t.Run("invalid resource", func(t *testing.T) {
+		_, err := plugin.ReadPSI(dir, "pids")
+		require.ErrorContains(t, err, "invalid PSI resource")
+	})
+}
+
+func TestReadIOStats(t *testing.T) {
+	t.Run("multiple devices", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"io.stat": "8:16 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0\n" +
+				"8:0 rbytes=90430464 wbytes=299008000 rios=8950 wios=1252 dbytes=50331648 dios=3021\n" +
+				"253:0 rbytes=4096 wbytes=0 rios=1 wios=0 dbytes=0 dios=0 cost.vrate=100.00\n",
+		})
+		stats, err := plugin.ReadIOStats(dir)
+		require.NoError(t, err)
+		require.Equal(t, map[string]*plugin.IODeviceStats{
+			"8:16": {
+				Rbytes: 1459200,
+				Wbytes: 314773504,
+				Rios:   192,
+				Wios:   353,
+			},
+			"8:0": {
+				Rbytes: 90430464,
+				Wbytes: 299008000,
+				Rios:   8950,
+				Wios:   1252,
+				Dbytes: 50331648,
+				Dios:   3021,
+			},
+			"253:0": {
+				Rbytes: 4096,
+				Rios:   1,
+			},
+		}, stats)
+	})
+
+	t.Run("missing io.stat", func(t *testing.T) {
+		stats, err := plugin.ReadIOStats(t.TempDir())
+		require.NoError(t, err)
+		require.Empty(t, stats)
+	})
+
+	t.Run("malformed io.stat", func(t *testing.T) {
+		dir := writeCgroupFiles(t, map[string]string{
+			"io.stat": "8:0 rbytes=1 wbytes=2\n8:16 rbytes\n",
+		})
+		_, err := plugin.ReadIOStats(dir)
+		require.ErrorContains(t, err, "io.stat:2")
+	})
+}
+
+func writeCgroupFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
@@ -0,0 +1,107 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugin_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/nri/pkg/api"
+	"github.com/containerd/nri/pkg/plugin"
+)
+
+func TestQoSCgroupPath(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		parent string
+		result string
+		fail   bool
+	}{
+		{
+			name:   "cgroupfs burstable",
+			parent: "/kubepods/burstable/pod8e3ab96a-5d1a-4b4b-9d5c-1b9d5b8c3c11",
+			result: "/kubepods/burstable",
+		},
+		{
+			name:   "cgroupfs besteffort",
+			parent: "/kubepods/besteffort/pod8e3ab96a-5d1a-4b4b-9d5c-1b9d5b8c3c11",
+			result: "/kubepods/besteffort",
+		},
+		{
+			name:   "cgroupfs guaranteed",
+			parent: "/kubepods/pod8e3ab96a-5d1a-4b4b-9d5c-1b9d5b8c3c11",
+			result: "/kubepods",
+		},
+		{
+			name:   "cgroupfs trailing slash",
+			parent: "/kubepods/burstable/pod8e3ab96a-5d1a-4b4b-9d5c-1b9d5b8c3c11/",
+			result: "/kubepods/burstable",
+		},
+		{
+			name:   "systemd burstable",
+			parent: "kubepods-burstable-pod8e3ab96a_5d1a_4b4b_9d5c_1b9d5b8c3c11.slice",
+			result: "kubepods-burstable.slice",
+		},
+		{
+			name:   "systemd besteffort",
+			parent: "kubepods-besteffort-pod8e3ab96a_5d1a_4b4b_9d5c_1b9d5b8c3c11.slice",
+			result: "kubepods-besteffort.slice",
+		},
+		{
+			name:   "systemd guaranteed",
+			parent: "kubepods-pod8e3ab96a_5d1a_4b4b_9d5c_1b9d5b8c3c11.slice",
+			result: "kubepods.slice",
+		},
+		{
+			name:   "systemd full path",
+			parent: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod8e3ab96a.slice",
+			result: "/kubepods.slice/kubepods-burstable.slice",
+		},
+		{
+			name: "no cgroup parent",
+			fail: true,
+		},
+		{
+			name:   "not a pod cgroupfs path",
+			parent: "/kubepods/burstable",
+			fail:   true,
+		},
+		{
+			name:   "not a pod systemd slice",
+			parent: "kubepods-burstable.slice",
+			fail:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &api.PodSandbox{
+				Name:      "pod",
+				Namespace: "default",
+				Linux: &api.LinuxPodSandbox{
+					CgroupParent: tc.parent,
+				},
+			}
+			result, err := plugin.QoSCgroupPath(pod)
+			if tc.fail {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
@@ -37,6 +37,11 @@ type DefaultValidatorConfig struct {
 	Enable bool `yaml:"enable" toml:"enable"`
 	// RejectOCIHookAdjustment fails validation if OCI hooks are adjusted.
 	RejectOCIHookAdjustment bool `yaml:"rejectOCIHookAdjustment" toml:"reject_oci_hook_adjustment"`
+	// AllowedOCIHookPaths, if set, lists the only hook paths plugins can
+	// inject OCI hooks with. Injecting a hook with any other path fails
+	// validation. Hooks with these paths are allowed even if OCI hook
+	// adjustment is otherwise rejected.
+	AllowedOCIHookPaths []string `yaml:"allowedOCIHookPaths" toml:"allowed_oci_hook_paths"`
 	// RejectRuntimeDefaultSeccompAdjustment fails validation if a runtime default seccomp
 	// policy is adjusted.
 	RejectRuntimeDefaultSeccompAdjustment bool `yaml:"rejectRuntimeDefaultSeccompAdjustment" toml:"reject_runtime_default_seccomp_adjustment"`
@@ -50,6 +55,20 @@ type DefaultValidatorConfig struct {
 	RejectNamespaceAdjustment bool `yaml:"rejectNamespaceAdjustment" toml:"reject_namespace_adjustment"`
 	// RejectSysctlAdjustment fails validation if any plugin adjusts sysctls
 	RejectSysctlAdjustment bool `yaml:"rejectSysctlAdjustment" toml:"reject_sysctl_adjustment"`
+	// MaxOCIHooks limits the number of OCI hooks a plugin can inject into a
+	// container. 0 means unlimited.
+	MaxOCIHooks int `yaml:"maxOCIHooks" toml:"max_oci_hooks"`
+	// MaxOCIHookCommandLength limits the length of the command, IOW the path
+	// and arguments joined by spaces, of each OCI hook a plugin injects. 0
+	// means unlimited.
+	MaxOCIHookCommandLength int `yaml:"maxOCIHookCommandLength" toml:"max_oci_hook_command_length"`
+	// MaxArgsLength limits the total length of the arguments, joined by spaces,
+	// a plugin sets for a container. 0 means unlimited.
+	MaxArgsLength int `yaml:"maxArgsLength" toml:"max_args_length"`
+	// RequiredMountOptions lists options, for instance nosuid or nodev, which
+	// all mounts added or adjusted by plugins must have or otherwise validation
+	// will fail.
+	RequiredMountOptions []string `yaml:"requiredMountOptions" toml:"required_mount_options"`
 	// RequiredPlugins list globally required plugins. These must be present
 	// or otherwise validation will fail.
 	// WARNING: This is a global setting and will affect all containers. In
@@ -63,6 +82,10 @@ type DefaultValidatorConfig struct {
 	// TolerateMissingPlugins is an optional annotation key. If set, it can
 	// be used to annotate containers to tolerate missing required plugins.
 	TolerateMissingAnnotation string `yaml:"tolerateMissingPluginsAnnotation" toml:"tolerate_missing_plugins_annotation"`
+	// RequiredPodAnnotations lists annotations, for instance an ownership
+	// annotation for traceability, which pods must have for their containers
+	// to be adjusted. Adjusting containers of other pods fails validation.
+	RequiredPodAnnotations []string `yaml:"requiredPodAnnotations" toml:"required_pod_annotations"`
 }
 
 // DefaultValidator implements default validation.
@@ -118,11 +141,26 @@ func (v *DefaultValidator) ValidateContainerAdjustment(ctx context.Context, req
 		return err
 	}
 
+	if err := v.validateRequiredPodAnnotations(req); err != nil {
+		log.Errorf(ctx, "rejecting adjustment: %v", err)
+		return err
+	}
+
 	if err := v.validateSysctl(req); err != nil {
 		log.Errorf(ctx, "rejecting adjustment: %v", err)
 		return err
 	}
 
+	if err := v.validateMounts(req); err != nil {
+		log.Errorf(ctx, "rejecting adjustment: %v", err)
+		return err
+	}
+
+	if err := v.validateLimits(req); err != nil {
+		log.Errorf(ctx, "rejecting adjustment: %v", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -131,7 +169,7 @@ func (v *DefaultValidator) validateOCIHooks(req *api.ValidateContainerAdjustment
 		return nil
 	}
 
-	if !v.cfg.RejectOCIHookAdjustment {
+	if !v.cfg.RejectOCIHookAdjustment && len(v.cfg.AllowedOCIHookPaths) == 0 {
 		return nil
 	}
 
@@ -148,7 +186,18 @@ func (v *DefaultValidator) validateOCIHooks(req *api.ValidateContainerAdjustment
 		offender = fmt.Sprintf("plugins %q", owners)
 	}
 
-	return fmt.Errorf("%w: %s attempted restricted OCI hook injection", ErrValidation, offender)
+	if len(v.cfg.AllowedOCIHookPaths) == 0 {
+		return fmt.Errorf("%w: %s attempted restricted OCI hook injection", ErrValidation, offender)
+	}
+
+	for _, h := range hookList(req.Adjust.Hooks) {
+		if !slices.Contains(v.cfg.AllowedOCIHookPaths, h.Path) {
+			return fmt.Errorf("%w: %s attempted injecting OCI hook with unexpected path %q",
+				ErrValidation, offender, h.Path)
+		}
+	}
+
+	return nil
 }
 
 func (v *DefaultValidator) validateSeccompPolicy(req *api.ValidateContainerAdjustmentRequest) error {
@@ -236,6 +285,121 @@ func (v *DefaultValidator) validateSysctl(req *api.ValidateContainerAdjustmentRe
 	return fmt.Errorf("%w: attempted restricted sysctl adjustment by plugin(s) %s", ErrValidation, strings.Join(owners, ", "))
 }
 
+func (v *DefaultValidator) validateMounts(req *api.ValidateContainerAdjustmentRequest) error {
+	if req.Adjust == nil {
+		return nil
+	}
+
+	if len(v.cfg.RequiredMountOptions) == 0 {
+		return nil
+	}
+
+	for _, m := range req.Adjust.Mounts {
+		if _, marked := api.IsMarkedForRemoval(m.Destination); marked {
+			continue
+		}
+
+		var missing []string
+		for _, opt := range v.cfg.RequiredMountOptions {
+			if !slices.Contains(m.Options, opt) {
+				missing = append(missing, opt)
+			}
+		}
+
+		if len(missing) == 0 {
+			continue
+		}
+
+		owner, claimed := req.Owners.MountOwner(req.Container.Id, m.Destination)
+		if !claimed {
+			owner = "<unknown>"
+		}
+
+		return fmt.Errorf("%w: plugin %q added mount %q without required options %s",
+			ErrValidation, owner, m.Destination, strings.Join(missing, ","))
+	}
+
+	return nil
+}
+
+func (v *DefaultValidator) validateRequiredPodAnnotations(req *api.ValidateContainerAdjustmentRequest) error {
+	if req.Adjust.SizeVT() == 0 {
+		return nil
+	}
+
+	if len(v.cfg.RequiredPodAnnotations) == 0 {
+		return nil
+	}
+
+	annotations := req.GetPod().GetAnnotations()
+	missing := []string{}
+
+	for _, key := range v.cfg.RequiredPodAnnotations {
+		if _, ok := annotations[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: pod %s/%s lacks required annotation(s) %s",
+		ErrValidation, req.GetPod().GetNamespace(), req.GetPod().GetName(), strings.Join(missing, ", "))
+}
+
+func (v *DefaultValidator) validateLimits(req *api.ValidateContainerAdjustmentRequest) error {
+	if req.Adjust == nil {
+		return nil
+	}
+
+	if maxHooks, maxCmd := v.cfg.MaxOCIHooks, v.cfg.MaxOCIHookCommandLength; maxHooks > 0 || maxCmd > 0 {
+		hooks := hookList(req.Adjust.Hooks)
+		owner, _ := req.Owners.HooksOwner(req.Container.Id)
+
+		if maxHooks > 0 && len(hooks) > maxHooks {
+			return fmt.Errorf("%w: plugin(s) %q attempted injecting %d OCI hooks, exceeding limit %d",
+				ErrValidation, owner, len(hooks), maxHooks)
+		}
+
+		if maxCmd > 0 {
+			for _, h := range hooks {
+				if cmdLen := len(strings.Join(append([]string{h.Path}, h.Args...), " ")); cmdLen > maxCmd {
+					return fmt.Errorf("%w: plugin(s) %q attempted injecting OCI hook %q with "+
+						"command length %d, exceeding limit %d", ErrValidation, owner, h.Path, cmdLen, maxCmd)
+				}
+			}
+		}
+	}
+
+	if maxArgs := v.cfg.MaxArgsLength; maxArgs > 0 {
+		if argsLen := len(strings.Join(req.Adjust.Args, " ")); argsLen > maxArgs {
+			owner, _ := req.Owners.ArgsOwner(req.Container.Id)
+			return fmt.Errorf("%w: plugin %q attempted setting arguments of length %d, exceeding limit %d",
+				ErrValidation, owner, argsLen, maxArgs)
+		}
+	}
+
+	return nil
+}
+
+// hookList returns all hooks of all types.
+func hookList(hooks *api.Hooks) []*api.Hook {
+	if hooks == nil {
+		return nil
+	}
+
+	var list []*api.Hook
+	list = append(list, hooks.Prestart...) //nolint:staticcheck // ignore SA1019: Prestart is deprecated
+	list = append(list, hooks.CreateRuntime...)
+	list = append(list, hooks.CreateContainer...)
+	list = append(list, hooks.StartContainer...)
+	list = append(list, hooks.Poststart...)
+	list = append(list, hooks.Poststop...)
+
+	return list
+}
+
 func (v *DefaultValidator) validateRequiredPlugins(req *api.ValidateContainerAdjustmentRequest) error {
 	var (
 		container = req.GetContainer().GetName()
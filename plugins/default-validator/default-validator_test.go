@@ -332,3 +332,494 @@ func TestValidateSysctl(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMounts(t *testing.T) {
+	type testCase struct {
+		name      string
+		cfg       *DefaultValidatorConfig
+		pod       *api.PodSandbox
+		container *api.Container
+		plugins   []*api.PluginInstance
+		adjust    *api.ContainerAdjustment
+		claim     func(f *api.FieldOwners) error
+		fail      bool
+	}
+
+	for _, tc := range []*testCase{
+		{
+			name: "mount with required options",
+			cfg: &DefaultValidatorConfig{
+				Enable:               true,
+				RequiredMountOptions: []string{"nosuid", "nodev"},
+			},
+			pod: &api.PodSandbox{
+				Id:        "pod-id",
+				Name:      "pod-name",
+				Namespace: "pod-namespace",
+			},
+			container: &api.Container{
+				Id:   "container-id",
+				Name: "container-name",
+			},
+			plugins: []*api.PluginInstance{
+				{
+					Name:  "plugin1",
+					Index: "00",
+				},
+			},
+			adjust: &api.ContainerAdjustment{
+				Mounts: []*api.Mount{
+					{
+						Destination: "/data",
+						Source:      "/host/data",
+						Type:        "bind",
+						Options:     []string{"rbind", "nodev", "nosuid", "ro"},
+					},
+				},
+			},
+			claim: func(f *api.FieldOwners) error {
+				return f.ClaimMount("/data", "plugin1")
+			},
+		},
+		{
+			name: "mount without required options",
+			cfg: &DefaultValidatorConfig{
+				Enable:               true,
+				RequiredMountOptions: []string{"nosuid", "nodev"},
+			},
+			pod: &api.PodSandbox{
+				Id:        "pod-id",
+				Name:      "pod-name",
+				Namespace: "pod-namespace",
+			},
+			container: &api.Container{
+				Id:   "container-id",
+				Name: "container-name",
+			},
+			plugins: []*api.PluginInstance{
+				{
+					Name:  "plugin1",
+					Index: "00",
+				},
+			},
+			adjust: &api.ContainerAdjustment{
+				Mounts: []*api.Mount{
+					{
+						Destination: "/data",
+						Source:      "/host/data",
+						Type:        "bind",
+						Options:     []string{"rbind", "nodev", "rw"},
+					},
+				},
+			},
+			claim: func(f *api.FieldOwners) error {
+				return f.ClaimMount("/data", "plugin1")
+			},
+			fail: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewDefaultValidator(tc.cfg)
+			owners := &api.OwningPlugins{
+				Owners: make(map[string]*api.FieldOwners),
+			}
+			owners.Owners[tc.container.Id] = &api.FieldOwners{
+				Simple:   make(map[int32]string),
+				Compound: make(map[int32]*api.CompoundFieldOwners),
+			}
+			if tc.claim != nil {
+				require.NoError(t, tc.claim(owners.Owners[tc.container.Id]))
+			}
+
+			req := &api.ValidateContainerAdjustmentRequest{
+				Pod:       tc.pod,
+				Container: tc.container,
+				Plugins:   tc.plugins,
+				Adjust:    tc.adjust,
+				Owners:    owners,
+			}
+
+			err := v.validateMounts(req)
+			if tc.fail {
+				require.ErrorIs(t, err, ErrValidation)
+				require.ErrorContains(t, err, `plugin "plugin1" added mount "/data"`)
+				require.ErrorContains(t, err, "nosuid")
+				require.NotContains(t, err.Error(), "nodev")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRequiredPodAnnotations(t *testing.T) {
+	type testCase struct {
+		name      string
+		cfg       *DefaultValidatorConfig
+		pod       *api.PodSandbox
+		container *api.Container
+		adjust    *api.ContainerAdjustment
+		fail      bool
+	}
+
+	for _, tc := range []*testCase{
+		{
+			name: "pod with required annotation",
+			cfg: &DefaultValidatorConfig{
+				Enable:                 true,
+				RequiredPodAnnotations: []string{"example.com/owner"},
+			},
+			pod: &api.PodSandbox{
+				Id:        "pod-id",
+				Name:      "pod-name",
+				Namespace: "pod-namespace",
+				Annotations: map[string]string{
+					"example.com/owner": "team-a",
+				},
+			},
+			container: &api.Container{
+				Id:   "container-id",
+				Name: "container-name",
+			},
+			adjust: &api.ContainerAdjustment{
+				Annotations: map[string]string{
+					"foo": "bar",
+				},
+			},
+		},
+		{
+			name: "pod without required annotation",
+			cfg: &DefaultValidatorConfig{
+				Enable:                 true,
+				RequiredPodAnnotations: []string{"example.com/owner"},
+			},
+			pod: &api.PodSandbox{
+				Id:        "pod-id",
+				Name:      "pod-name",
+				Namespace: "pod-namespace",
+			},
+			container: &api.Container{
+				Id:   "container-id",
+				Name: "container-name",
+			},
+			adjust: &api.ContainerAdjustment{
+				Annotations: map[string]string{
+					"foo": "bar",
+				},
+			},
+			fail: true,
+		},
+		{
+			name: "pod without required annotation, no adjustment",
+			cfg: &DefaultValidatorConfig{
+				Enable:                 true,
+				RequiredPodAnnotations: []string{"example.com/owner"},
+			},
+			pod: &api.PodSandbox{
+				Id:        "pod-id",
+				Name:      "pod-name",
+				Namespace: "pod-namespace",
+			},
+			container: &api.Container{
+				Id:   "container-id",
+				Name: "container-name",
+			},
+			adjust: &api.ContainerAdjustment{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewDefaultValidator(tc.cfg)
+			req := &api.ValidateContainerAdjustmentRequest{
+				Pod:       tc.pod,
+				Container: tc.container,
+				Adjust:    tc.adjust,
+			}
+
+			err := v.validateRequiredPodAnnotations(req)
+			if tc.fail {
+				require.ErrorIs(t, err, ErrValidation)
+				require.ErrorContains(t, err, "example.com/owner")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	type testCase struct {
+		name   string
+		cfg    *DefaultValidatorConfig
+		adjust *api.ContainerAdjustment
+		claim  func(f *api.FieldOwners) error
+		fail   bool
+	}
+
+	hooks := func(paths ...string) *api.Hooks {
+		h := &api.Hooks{}
+		for _, p := range paths {
+			h.CreateRuntime = append(h.CreateRuntime, &api.Hook{Path: p})
+		}
+		return h
+	}
+	claimHooks := func(f *api.FieldOwners) error {
+		return f.ClaimHooks("plugin1")
+	}
+	claimArgs := func(f *api.FieldOwners) error {
+		return f.ClaimArgs("plugin1")
+	}
+
+	for _, tc := range []*testCase{
+		{
+			name: "hook count at limit",
+			cfg: &DefaultValidatorConfig{
+				Enable:      true,
+				MaxOCIHooks: 2,
+			},
+			adjust: &api.ContainerAdjustment{
+				Hooks: hooks("/bin/hook1", "/bin/hook2"),
+			},
+			claim: claimHooks,
+		},
+		{
+			name: "hook count over limit",
+			cfg: &DefaultValidatorConfig{
+				Enable:      true,
+				MaxOCIHooks: 2,
+			},
+			adjust: &api.ContainerAdjustment{
+				Hooks: hooks("/bin/hook1", "/bin/hook2", "/bin/hook3"),
+			},
+			claim: claimHooks,
+			fail:  true,
+		},
+		{
+			name: "hook command length at limit",
+			cfg: &DefaultValidatorConfig{
+				Enable:                  true,
+				MaxOCIHookCommandLength: len("/bin/hook --flag"),
+			},
+			adjust: &api.ContainerAdjustment{
+				Hooks: &api.Hooks{
+					Poststop: []*api.Hook{
+						{
+							Path: "/bin/hook",
+							Args: []string{"--flag"},
+						},
+					},
+				},
+			},
+			claim: claimHooks,
+		},
+		{
+			name: "hook command length over limit",
+			cfg: &DefaultValidatorConfig{
+				Enable:                  true,
+				MaxOCIHookCommandLength: len("/bin/hook --flag"),
+			},
+			adjust: &api.ContainerAdjustment{
+				Hooks: &api.Hooks{
+					Poststop: []*api.Hook{
+						{
+							Path: "/bin/hook",
+							Args: []string{"--flags"},
+						},
+					},
+				},
+			},
+			claim: claimHooks,
+			fail:  true,
+		},
+		{
+			name: "args length at limit",
+			cfg: &DefaultValidatorConfig{
+				Enable:        true,
+				MaxArgsLength: len("/bin/sh -c true"),
+			},
+			adjust: &api.ContainerAdjustment{
+				Args: []string{"/bin/sh", "-c", "true"},
+			},
+			claim: claimArgs,
+		},
+		{
+			name: "args length over limit",
+			cfg: &DefaultValidatorConfig{
+				Enable:        true,
+				MaxArgsLength: len("/bin/sh -c true"),
+			},
+			adjust: &api.ContainerAdjustment{
+				Args: []string{"/bin/sh", "-c", "false"},
+			},
+			claim: claimArgs,
+			fail:  true,
+		},
+		{
+			name: "unlimited by default",
+			cfg: &DefaultValidatorConfig{
+				Enable: true,
+			},
+			adjust: &api.ContainerAdjustment{
+				Hooks: hooks("/bin/hook1", "/bin/hook2", "/bin/hook3"),
+				Args:  []string{"/bin/sh", "-c", "sleep inf"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewDefaultValidator(tc.cfg)
+			owners := &api.OwningPlugins{
+				Owners: make(map[string]*api.FieldOwners),
+			}
+			owners.Owners["container-id"] = &api.FieldOwners{
+				Simple:   make(map[int32]string),
+				Compound: make(map[int32]*api.CompoundFieldOwners),
+			}
+			if tc.claim != nil {
+				require.NoError(t, tc.claim(owners.Owners["container-id"]))
+			}
+
+			req := &api.ValidateContainerAdjustmentRequest{
+				Pod: &api.PodSandbox{
+					Id:        "pod-id",
+					Name:      "pod-name",
+					Namespace: "pod-namespace",
+				},
+				Container: &api.Container{
+					Id:   "container-id",
+					Name: "container-name",
+				},
+				Adjust: tc.adjust,
+				Owners: owners,
+			}
+
+			err := v.validateLimits(req)
+			if tc.fail {
+				require.ErrorIs(t, err, ErrValidation)
+				require.ErrorContains(t, err, "plugin1")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateOCIHookPaths(t *testing.T) {
+	type testCase struct {
+		name      string
+		cfg       *DefaultValidatorConfig
+		pod       *api.PodSandbox
+		container *api.Container
+		plugins   []*api.PluginInstance
+		adjust    *api.ContainerAdjustment
+		claim     func(f *api.FieldOwners) error
+		fail      bool
+	}
+
+	for _, tc := range []*testCase{
+		{
+			name: "hook with allowed path",
+			cfg: &DefaultValidatorConfig{
+				Enable:                  true,
+				RejectOCIHookAdjustment: true,
+				AllowedOCIHookPaths:     []string{"/usr/libexec/known-hook"},
+			},
+			pod: &api.PodSandbox{
+				Id:        "pod-id",
+				Name:      "pod-name",
+				Namespace: "pod-namespace",
+			},
+			container: &api.Container{
+				Id:   "container-id",
+				Name: "container-name",
+			},
+			plugins: []*api.PluginInstance{
+				{
+					Name:  "plugin1",
+					Index: "00",
+				},
+			},
+			adjust: &api.ContainerAdjustment{
+				Hooks: &api.Hooks{
+					CreateRuntime: []*api.Hook{
+						{
+							Path: "/usr/libexec/known-hook",
+						},
+					},
+				},
+			},
+			claim: func(f *api.FieldOwners) error {
+				return f.ClaimHooks("plugin1")
+			},
+		},
+		{
+			name: "hook with unexpected path",
+			cfg: &DefaultValidatorConfig{
+				Enable:              true,
+				AllowedOCIHookPaths: []string{"/usr/libexec/known-hook"},
+			},
+			pod: &api.PodSandbox{
+				Id:        "pod-id",
+				Name:      "pod-name",
+				Namespace: "pod-namespace",
+			},
+			container: &api.Container{
+				Id:   "container-id",
+				Name: "container-name",
+			},
+			plugins: []*api.PluginInstance{
+				{
+					Name:  "plugin1",
+					Index: "00",
+				},
+			},
+			adjust: &api.ContainerAdjustment{
+				Hooks: &api.Hooks{
+					CreateRuntime: []*api.Hook{
+						{
+							Path: "/usr/libexec/known-hook",
+						},
+					},
+					Poststop: []*api.Hook{
+						{
+							Path: "/tmp/arbitrary-hook",
+						},
+					},
+				},
+			},
+			claim: func(f *api.FieldOwners) error {
+				return f.ClaimHooks("plugin1")
+			},
+			fail: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewDefaultValidator(tc.cfg)
+			owners := &api.OwningPlugins{
+				Owners: make(map[string]*api.FieldOwners),
+			}
+			owners.Owners[tc.container.Id] = &api.FieldOwners{
+				Simple:   make(map[int32]string),
+				Compound: make(map[int32]*api.CompoundFieldOwners),
+			}
+			if tc.claim != nil {
+				require.NoError(t, tc.claim(owners.Owners[tc.container.Id]))
+			}
+
+			req := &api.ValidateContainerAdjustmentRequest{
+				Pod:       tc.pod,
+				Container: tc.container,
+				Plugins:   tc.plugins,
+				Adjust:    tc.adjust,
+				Owners:    owners,
+			}
+
+			err := v.validateOCIHooks(req)
+			if tc.fail {
+				require.ErrorIs(t, err, ErrValidation)
+				require.ErrorContains(t, err, `plugin "plugin1"`)
+				require.ErrorContains(t, err, `"/tmp/arbitrary-hook"`)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}